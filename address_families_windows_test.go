@@ -0,0 +1,55 @@
+package water
+
+import (
+	"net/netip"
+	"testing"
+
+	"water/winipcfg"
+)
+
+func TestAddressFamilies(t *testing.T) {
+	v4 := netip.MustParsePrefix("10.0.0.0/24")
+	v6 := netip.MustParsePrefix("fd00::/64")
+	v4in6 := netip.MustParsePrefix("::ffff:10.0.0.0/120")
+
+	cases := []struct {
+		name     string
+		prefixes []netip.Prefix
+		want     []winipcfg.AddressFamily
+	}{
+		{"empty", nil, nil},
+		{"v4 only", []netip.Prefix{v4}, []winipcfg.AddressFamily{winipcfg.AF_INET}},
+		{"v6 only", []netip.Prefix{v6}, []winipcfg.AddressFamily{winipcfg.AF_INET6}},
+		{"dual stack", []netip.Prefix{v4, v6}, []winipcfg.AddressFamily{winipcfg.AF_INET, winipcfg.AF_INET6}},
+		{"v4-in-v6 counts as v4", []netip.Prefix{v4in6}, []winipcfg.AddressFamily{winipcfg.AF_INET}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := addressFamilies(c.prefixes)
+			if len(got) != len(c.want) {
+				t.Fatalf("addressFamilies(%v) = %v, want %v", c.prefixes, got, c.want)
+			}
+			for i := range got {
+				if got[i] != c.want[i] {
+					t.Fatalf("addressFamilies(%v) = %v, want %v", c.prefixes, got, c.want)
+				}
+			}
+		})
+	}
+}
+
+func TestDNSServersForFamily(t *testing.T) {
+	v4 := netip.MustParseAddr("8.8.8.8")
+	v6 := netip.MustParseAddr("2001:4860:4860::8888")
+	servers := []netip.Addr{v4, v6}
+
+	got4 := dnsServersForFamily(servers, winipcfg.AF_INET)
+	if len(got4) != 1 || got4[0] != v4 {
+		t.Fatalf("dnsServersForFamily(AF_INET) = %v, want [%v]", got4, v4)
+	}
+
+	got6 := dnsServersForFamily(servers, winipcfg.AF_INET6)
+	if len(got6) != 1 || got6[0] != v6 {
+		t.Fatalf("dnsServersForFamily(AF_INET6) = %v, want [%v]", got6, v6)
+	}
+}
@@ -0,0 +1,20 @@
+package water
+
+// BatchReader is an optional interface a ReadWriteCloser may implement to
+// receive several packets per call, amortizing the per-packet syscall and
+// wait overhead of the underlying driver. Callers should type-assert for it
+// rather than assuming it is always available.
+type BatchReader interface {
+	// ReadPackets fills buffs with up to len(buffs) packets, copying each
+	// packet's payload into buffs[i][offset:] and recording its length in
+	// sizes[i]. It returns the number of packets read.
+	ReadPackets(buffs [][]byte, sizes []int, offset int) (int, error)
+}
+
+// BatchWriter is an optional interface a ReadWriteCloser may implement to
+// submit several packets per call.
+type BatchWriter interface {
+	// WritePackets submits buffs[i][offset:] as one packet each, in order,
+	// and returns the number of packets written.
+	WritePackets(buffs [][]byte, offset int) (int, error)
+}
@@ -0,0 +1,65 @@
+package water
+
+import (
+	"net/netip"
+
+	"golang.org/x/sys/windows"
+)
+
+// PlatformSpecificParams defines Windows (Wintun) specific parameters for
+// Config.
+type PlatformSpecificParams struct {
+	// InterfaceName is the name given to, or reused from, the Wintun
+	// adapter. It takes priority over the outer Config.InterfaceName when
+	// both are set.
+	InterfaceName string
+
+	// Network is a single IPv4/IPv6 CIDR assigned to the adapter. It is kept
+	// for backward compatibility; prefer Addresses, which accepts any number
+	// of prefixes of either family. Ignored when Addresses is non-empty.
+	Network string
+
+	// Addresses lists every IPv4/IPv6 prefix to assign to the adapter.
+	Addresses []netip.Prefix
+
+	// Routes lists prefixes to route through the adapter. They are only
+	// installed when AutoRoute is set.
+	Routes []netip.Prefix
+
+	// AutoRoute installs Routes as on-link routes during Reconfigure, at
+	// RouteMetric, instead of requiring the caller to do so out of band
+	// (e.g. via route/netsh).
+	AutoRoute bool
+
+	// RouteMetric is the metric used for routes installed via AutoRoute.
+	RouteMetric uint32
+
+	// DNS lists DNS server addresses to assign to the adapter.
+	DNS []netip.Addr
+
+	// DNSSearch lists DNS search domains to assign to the adapter.
+	DNSSearch []string
+
+	// MTU overrides the adapter's MTU for every configured address family,
+	// if non-zero.
+	MTU int
+
+	// InterfaceMetric overrides the adapter's automatic interface metric,
+	// if non-zero.
+	InterfaceMetric uint32
+
+	// RingCapacity overrides the Wintun session's ring capacity, in bytes,
+	// if non-zero. Must be a power of two between 128 KiB and 64 MiB; see
+	// validateRingCapacity. Larger rings reduce drop rates under bursty
+	// load at the cost of memory.
+	RingCapacity uint32
+
+	// TunnelType overrides the driver-reported tunnel type, if non-empty,
+	// letting products other than WireGuard avoid appearing as "WireGuard"
+	// adapters in Windows UI. Defaults to WintunTunnelType.
+	TunnelType string
+
+	// RequestedGUID overrides the adapter's GUID, if non-nil. Defaults to
+	// WintunStaticRequestedGUID.
+	RequestedGUID *windows.GUID
+}
@@ -0,0 +1,79 @@
+package water
+
+import (
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+	"golang.zx2c4.com/wintun"
+)
+
+// wintunAdapterDescription is the driver description Windows reports for
+// every Wintun adapter, regardless of which process or tunnel type created
+// it. ListAdapters uses it to tell Wintun adapters apart from everything
+// else GetAdaptersAddresses returns.
+const wintunAdapterDescription = "Wintun Userspace Tunnel"
+
+// AdapterInfo describes a Wintun adapter discovered by ListAdapters.
+type AdapterInfo struct {
+	Name string
+	GUID windows.GUID
+	LUID uint64
+}
+
+// DriverVersion returns the version of the currently loaded Wintun driver.
+func DriverVersion() (uint32, error) {
+	return wintun.RunningVersion()
+}
+
+// ListAdapters enumerates every Wintun adapter present on the system,
+// regardless of which process or tunnel type created it. This lets callers
+// find and clean up adapters left behind by processes that exited without
+// calling Close.
+func ListAdapters() ([]AdapterInfo, error) {
+	var size uint32 = 15000
+	var buf []byte
+	for {
+		buf = make([]byte, size)
+		err := windows.GetAdaptersAddresses(windows.AF_UNSPEC,
+			windows.GAA_FLAG_SKIP_ANYCAST|windows.GAA_FLAG_SKIP_MULTICAST|windows.GAA_FLAG_SKIP_DNS_SERVER,
+			0, (*windows.IpAdapterAddresses)(unsafe.Pointer(&buf[0])), &size)
+		if err == nil {
+			break
+		}
+		if err != windows.ERROR_BUFFER_OVERFLOW {
+			return nil, fmt.Errorf("Error enumerating adapters: %w", err)
+		}
+	}
+
+	var adapters []AdapterInfo
+	for aa := (*windows.IpAdapterAddresses)(unsafe.Pointer(&buf[0])); aa != nil; aa = aa.Next {
+		if windows.UTF16PtrToString(aa.Description) != wintunAdapterDescription {
+			continue
+		}
+		info := AdapterInfo{
+			Name: windows.UTF16PtrToString(aa.FriendlyName),
+			LUID: aa.Luid,
+		}
+		if guid, err := windows.GUIDFromString(windows.BytePtrToString(aa.AdapterName)); err == nil {
+			info.GUID = guid
+		}
+		adapters = append(adapters, info)
+	}
+	return adapters, nil
+}
+
+// DeleteAdapter force-removes the named Wintun adapter, e.g. one left
+// behind by a process that crashed before calling (*NativeTun).Close.
+func DeleteAdapter(name string) error {
+	adapter, err := wintun.OpenAdapter(name)
+	if err != nil {
+		return fmt.Errorf("Error opening adapter %q: %w", name, err)
+	}
+	return adapter.Close()
+}
+
+// UninstallDriver removes the Wintun driver from the system.
+func UninstallDriver() error {
+	return wintun.Uninstall()
+}
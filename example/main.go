@@ -7,14 +7,15 @@ import (
 	"golang.org/x/net/ipv4"
 	"log"
 	"net"
-	"os"
-	"os/exec"
 )
 
 const (
 	// I use TUN interface, so only plain IP packet, no ethernet header + mtu is set to 1300
 	BUFFERSIZE = 1500
 	MTU        = "1300"
+	// BATCHSIZE is how many packets to pull per call when the platform's
+	// ReadWriteCloser implements water.BatchReader/water.BatchWriter.
+	BATCHSIZE = 8
 )
 
 var (
@@ -25,17 +26,6 @@ var (
 	remoteSubNet = flag.String("remote_subnet", "", "Remote server sub net")
 )
 
-func runIP(name string,args ...string) {
-	cmd := exec.Command(name,args...)
-	cmd.Stderr = os.Stderr
-	cmd.Stdout = os.Stdout
-	cmd.Stdin = os.Stdin
-	err := cmd.Run()
-	if nil != err {
-		log.Fatalln("Error running /sbin/ip:", err,name,args)
-	}
-}
-
 func main() {
 	flag.Parse()
 	// check if we have anything
@@ -58,11 +48,10 @@ func main() {
 		log.Fatalln("Unable to allocate TUN interface:", err)
 	}
 	log.Println("Interface allocated:", iface.Name())
-	// set interface parameters
-	//netlink.AddrAdd()
-	//runIP("/sbin/ifconfig",iface.Name(),*tunIP,*tunIP,"up")
-	//runIP("/sbin/route","add","-net",*tunMask,"-iface",iface.Name())
-	//runIP("sh","-c",fmt.Sprintf("route add -net %s -iface %s",*remoteSubNet,iface.Name()))
+	// On Windows, addresses/routes/DNS/MTU set in PlatformSpecificParams are
+	// applied in-process via WinTun.Reconfigure instead of shelling out to
+	// ifconfig/route/netsh. This example sets none of them, so -tun_ip is
+	// not applied to the interface.
 	// reslove remote addr
 	remoteAddr, err := net.ResolveUDPAddr("udp", fmt.Sprintf("[%s]:%v", *remoteIP, *remotePort))
 	if nil != err {
@@ -74,7 +63,7 @@ func main() {
 	if nil != err {
 		log.Fatalln("Unable to get UDP socket:", err)
 	}
-	log.Println("listen udp",lstnAddr.String())
+	log.Println("listen udp", lstnAddr.String())
 	lstnConn, err := net.ListenUDP("udp", lstnAddr)
 	if nil != err {
 		log.Fatalln("Unable to listen on UDP socket:", err)
@@ -87,7 +76,7 @@ func main() {
 			n, addr, err := lstnConn.ReadFromUDP(buf)
 			// just debug
 			header, _ := ipv4.ParseHeader(buf[:n])
-			log.Printf("Recv Data: %s -> %s(%s -> %s)\n",addr.String(),lstnConn.LocalAddr(),header.Src,header.Dst)
+			log.Printf("Recv Data: %s -> %s(%s -> %s)\n", addr.String(), lstnConn.LocalAddr(), header.Src, header.Dst)
 			if err != nil || n == 0 {
 				fmt.Println("Error: ", err)
 				continue
@@ -95,11 +84,18 @@ func main() {
 			// write to TUN interface
 			_, err = iface.Write(buf[:n])
 			if err != nil {
-				log.Println("write tun iface ",err)
+				log.Println("write tun iface ", err)
 			}
 		}
 	}()
 	// and one more loop
+	br, batched := iface.ReadWriteCloser.(water.BatchReader)
+	bw, _ := iface.ReadWriteCloser.(water.BatchWriter)
+	if batched {
+		readBatch(iface, br, bw, lstnConn, remoteAddr)
+		return
+	}
+
 	packet := make([]byte, BUFFERSIZE)
 	for {
 		plen, err := iface.Read(packet)
@@ -108,7 +104,7 @@ func main() {
 		}
 		// debug :)
 		header, _ := ipv4.ParseHeader(packet[:plen])
-		log.Printf("Write data: %s -> %s(%s -> %s)\n", lstnConn.LocalAddr(),remoteAddr ,header.Src,header.Dst)
+		log.Printf("Write data: %s -> %s(%s -> %s)\n", lstnConn.LocalAddr(), remoteAddr, header.Src, header.Dst)
 		if header.Src.String() == header.Dst.String() {
 			iface.Write(packet[:plen])
 		} else {
@@ -117,3 +113,35 @@ func main() {
 		}
 	}
 }
+
+// readBatch mirrors the single-packet loop in main, but pulls up to
+// BATCHSIZE packets per br.ReadPackets call. This is the path platforms
+// like Windows' Wintun take, amortizing the per-packet syscall/wait
+// overhead that iface.Read pays on every call.
+func readBatch(iface *water.Interface, br water.BatchReader, bw water.BatchWriter, lstnConn *net.UDPConn, remoteAddr *net.UDPAddr) {
+	buffs := make([][]byte, BATCHSIZE)
+	sizes := make([]int, BATCHSIZE)
+	for i := range buffs {
+		buffs[i] = make([]byte, BUFFERSIZE)
+	}
+	for {
+		n, err := br.ReadPackets(buffs, sizes, 0)
+		if err != nil {
+			break
+		}
+		for i := 0; i < n; i++ {
+			packet := buffs[i][:sizes[i]]
+			header, _ := ipv4.ParseHeader(packet)
+			log.Printf("Write data: %s -> %s(%s -> %s)\n", lstnConn.LocalAddr(), remoteAddr, header.Src, header.Dst)
+			if header.Src.String() == header.Dst.String() {
+				if bw != nil {
+					bw.WritePackets([][]byte{packet}, 0)
+				} else {
+					iface.Write(packet)
+				}
+			} else {
+				lstnConn.WriteToUDP(packet, remoteAddr)
+			}
+		}
+	}
+}
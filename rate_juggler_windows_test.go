@@ -0,0 +1,33 @@
+package water
+
+import "testing"
+
+func TestRateJugglerUpdateAt(t *testing.T) {
+	var rate rateJuggler
+
+	// Still inside the measurement window: current must not be published
+	// yet, but bytes keep accumulating.
+	rate.updateAt(1000, 0)
+	rate.updateAt(1000, int64(rateMeasurementGranularity/2))
+	if rate.current != 0 {
+		t.Fatalf("current = %d before granularity elapsed, want 0", rate.current)
+	}
+	if rate.nextByteCount != 2000 {
+		t.Fatalf("nextByteCount = %d, want 2000", rate.nextByteCount)
+	}
+
+	// Once a full granularity period has elapsed, current should reflect
+	// bytes-per-second over the accumulated window, and the window resets.
+	end := int64(rateMeasurementGranularity)
+	rate.updateAt(0, end)
+	want := 2000 * uint64(Second/Nanosecond) / uint64(end)
+	if rate.current != want {
+		t.Fatalf("current = %d, want %d", rate.current, want)
+	}
+	if rate.nextByteCount != 0 {
+		t.Fatalf("nextByteCount = %d after measurement, want 0", rate.nextByteCount)
+	}
+	if rate.nextStartTime != end {
+		t.Fatalf("nextStartTime = %d, want %d", rate.nextStartTime, end)
+	}
+}
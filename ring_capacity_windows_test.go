@@ -0,0 +1,26 @@
+package water
+
+import "testing"
+
+func TestValidateRingCapacity(t *testing.T) {
+	cases := []struct {
+		name     string
+		capacity uint32
+		wantErr  bool
+	}{
+		{"below minimum", minRingCapacity / 2, true},
+		{"above maximum", maxRingCapacity * 2, true},
+		{"not a power of two", minRingCapacity + minRingCapacity/2, true},
+		{"minimum boundary", minRingCapacity, false},
+		{"maximum boundary", maxRingCapacity, false},
+		{"default", defaultRingCapacity, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := validateRingCapacity(c.capacity)
+			if (err != nil) != c.wantErr {
+				t.Fatalf("validateRingCapacity(%#x) error = %v, wantErr %v", c.capacity, err, c.wantErr)
+			}
+		})
+	}
+}
@@ -38,28 +38,172 @@ func (w *WinTun) Close() error {
 	return w.tun.Close()
 }
 
+func (w *WinTun) ReadPackets(buffs [][]byte, sizes []int, offset int) (int, error) {
+	return w.tun.ReadPackets(buffs, sizes, offset)
+}
+
+func (w *WinTun) WritePackets(buffs [][]byte, offset int) (int, error) {
+	return w.tun.WritePackets(buffs, offset)
+}
+
 func openTunDev(config Config) (*Interface, error) {
-	tun, err := CreateTUNWithRequestedGUID(config.InterfaceName, WintunStaticRequestedGUID, 0)
-	if err != nil {
-		return nil, err
+	params := config.PlatformSpecificParams
+
+	ifname := params.InterfaceName
+	if ifname == "" {
+		ifname = config.InterfaceName
+	}
+
+	requestedGUID := params.RequestedGUID
+	if requestedGUID == nil {
+		requestedGUID = WintunStaticRequestedGUID
 	}
-	network := config.PlatformSpecificParams.Network
-	ipPrefix, err := netip.ParsePrefix(network)
+	tun, err := CreateTUNWithRequestedGUID(ifname, params.TunnelType, requestedGUID, 0, params.RingCapacity, 0)
 	if err != nil {
 		return nil, err
 	}
-	link := winipcfg.LUID(tun.LUID())
-	err = link.SetIPAddresses(ipPrefix)
-	if err != nil {
+	wt := &WinTun{tun: tun}
+
+	if params.Network != "" && len(params.Addresses) == 0 {
+		ipPrefix, err := netip.ParsePrefix(params.Network)
+		if err != nil {
+			return nil, err
+		}
+		params.Addresses = []netip.Prefix{ipPrefix}
+	}
+	if err := wt.Reconfigure(params); err != nil {
 		return nil, err
 	}
+
 	return &Interface{
 		isTAP:           false,
-		ReadWriteCloser: &WinTun{tun: tun},
-		name:            config.InterfaceName,
+		ReadWriteCloser: wt,
+		name:            ifname,
 	}, nil
 }
 
+// Reconfigure applies cfg to the adapter backing w, mirroring
+// wireguard-windows' addressconfig flow. When cfg.Addresses/cfg.AutoRoute
+// are set, it first flushes whatever addresses/routes are left over from
+// the LUID's previous configuration in *both* families — not just the
+// families cfg happens to mention, since a prior Reconfigure may have left
+// the adapter in a different state than the one being requested now — then
+// applies the new addresses/routes. A cfg that only touches DNS/MTU leaves
+// existing addresses and routes untouched. Callers no longer need to shell
+// out to route/netsh to bring the interface into the desired state.
+func (w *WinTun) Reconfigure(cfg PlatformSpecificParams) error {
+	link := winipcfg.LUID(w.tun.LUID())
+
+	allFamilies := []winipcfg.AddressFamily{winipcfg.AF_INET, winipcfg.AF_INET6}
+
+	if len(cfg.Addresses) > 0 {
+		for _, family := range allFamilies {
+			if err := link.FlushAddresses(family); err != nil {
+				return fmt.Errorf("Error flushing addresses: %w", err)
+			}
+		}
+		if err := link.SetIPAddresses(cfg.Addresses...); err != nil {
+			return fmt.Errorf("Error setting addresses: %w", err)
+		}
+	}
+
+	// AutoRoute installs each route as on-link, so the next-hop is the
+	// unspecified address for the route's family, not the route's own
+	// network address (which isn't a valid gateway).
+	//
+	// Manual verification: with AutoRoute: true and a Routes entry, `route
+	// print` (or `netsh interface ipv4 show route`) on the box should list
+	// the prefix against this adapter's interface with gateway 0.0.0.0 (or
+	// :: for IPv6) at RouteMetric, and traffic to that prefix should egress
+	// the adapter without any route/netsh step by the caller.
+	if cfg.AutoRoute {
+		for _, family := range allFamilies {
+			if err := link.FlushRoutes(family); err != nil {
+				return fmt.Errorf("Error flushing routes: %w", err)
+			}
+		}
+		for _, route := range cfg.Routes {
+			nextHop := netip.IPv4Unspecified()
+			if route.Addr().Is6() && !route.Addr().Is4In6() {
+				nextHop = netip.IPv6Unspecified()
+			}
+			if err := link.AddRoute(route, nextHop, cfg.RouteMetric); err != nil {
+				return fmt.Errorf("Error adding route %s: %w", route, err)
+			}
+		}
+	}
+
+	if len(cfg.DNS) > 0 || len(cfg.DNSSearch) > 0 {
+		for _, family := range allFamilies {
+			servers := dnsServersForFamily(cfg.DNS, family)
+			if len(servers) == 0 && len(cfg.DNSSearch) == 0 {
+				continue
+			}
+			if err := link.SetDNS(family, servers, cfg.DNSSearch); err != nil {
+				return fmt.Errorf("Error setting DNS: %w", err)
+			}
+		}
+	}
+
+	if cfg.MTU > 0 || cfg.InterfaceMetric > 0 {
+		mtuFamilies := addressFamilies(cfg.Addresses)
+		if len(mtuFamilies) == 0 {
+			mtuFamilies = allFamilies
+		}
+		for _, family := range mtuFamilies {
+			iface, err := link.IPInterface(family)
+			if err != nil {
+				return fmt.Errorf("Error getting IP interface: %w", err)
+			}
+			if cfg.MTU > 0 {
+				iface.NLMTU = uint32(cfg.MTU)
+			}
+			if cfg.InterfaceMetric > 0 {
+				iface.UseAutomaticMetric = false
+				iface.Metric = cfg.InterfaceMetric
+			}
+			if err := iface.Set(); err != nil {
+				return fmt.Errorf("Error applying IP interface settings: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// dnsServersForFamily returns the servers in servers belonging to family.
+func dnsServersForFamily(servers []netip.Addr, family winipcfg.AddressFamily) []netip.Addr {
+	var filtered []netip.Addr
+	for _, s := range servers {
+		isV4 := s.Is4() || s.Is4In6()
+		if (family == winipcfg.AF_INET) == isV4 {
+			filtered = append(filtered, s)
+		}
+	}
+	return filtered
+}
+
+// addressFamilies returns the set of address families present in prefixes,
+// in AF_INET, AF_INET6 order.
+func addressFamilies(prefixes []netip.Prefix) []winipcfg.AddressFamily {
+	var have4, have6 bool
+	for _, p := range prefixes {
+		if p.Addr().Is4() || p.Addr().Is4In6() {
+			have4 = true
+		} else {
+			have6 = true
+		}
+	}
+	families := make([]winipcfg.AddressFamily, 0, 2)
+	if have4 {
+		families = append(families, winipcfg.AF_INET)
+	}
+	if have6 {
+		families = append(families, winipcfg.AF_INET6)
+	}
+	return families
+}
+
 const (
 	rateMeasurementGranularity = uint64((Second / 2) / Nanosecond)
 	spinloopRateThreshold      = 800000000 / 8                         // 800mbps
@@ -85,7 +229,49 @@ type NativeTun struct {
 	running   sync.WaitGroup
 	closeOnce sync.Once
 	close     atomic.Value
-	forcedMTU int
+	forcedMTU int32 // accessed atomically; written from both caller goroutines (ForceMTU) and the winipcfg callback thread (interfaceChanged)
+	rate      rateJuggler
+
+	ifaceChangeCB *winipcfg.ChangeCallback
+	routeChangeCB *winipcfg.ChangeCallback
+}
+
+// rateJuggler tracks a rolling estimate of throughput so that Read can
+// decide whether it is worth busy-spinning for the next packet instead of
+// waiting on readWait. current is recomputed every
+// rateMeasurementGranularity based on bytes moved since nextStartTime.
+type rateJuggler struct {
+	current       uint64
+	nextByteCount uint64
+	nextStartTime int64
+	changing      int32
+}
+
+// Rate returns the most recently measured throughput, in bytes per second.
+func (tun *NativeTun) Rate() uint64 {
+	return atomic.LoadUint64(&tun.rate.current)
+}
+
+func (rate *rateJuggler) update(packetLen uint64) {
+	rate.updateAt(packetLen, nanotime())
+}
+
+// updateAt implements update against an explicit timestamp, so the
+// granularity/threshold math can be exercised without depending on
+// nanotime.
+func (rate *rateJuggler) updateAt(packetLen uint64, now int64) {
+	total := atomic.AddUint64(&rate.nextByteCount, packetLen)
+	period := uint64(now - atomic.LoadInt64(&rate.nextStartTime))
+	if period < rateMeasurementGranularity {
+		return
+	}
+	if !atomic.CompareAndSwapInt32(&rate.changing, 0, 1) {
+		return
+	}
+	atomic.StoreInt64(&rate.nextStartTime, now)
+	atomic.StoreUint64(&rate.current, total*uint64(Second/Nanosecond)/period)
+	atomic.StoreUint64(&rate.nextByteCount, 0)
+	atomic.StoreInt32(&rate.changing, 0)
 }
 
 //go:linkname procyield runtime.procyield
@@ -94,10 +280,54 @@ func procyield(cycles uint32)
 //go:linkname nanotime runtime.nanotime
 func nanotime() int64
 
-// CreateTUNWithRequestedGUID creates a Wintun interface with the given name and
-// a requested GUID. Should a Wintun interface with the same name exist, it is reused.
-func CreateTUNWithRequestedGUID(ifname string, requestedGUID *windows.GUID, mtu int) (*NativeTun, error) {
-	wt, err := wintun.CreateAdapter(ifname, WintunTunnelType, requestedGUID)
+const (
+	// minRingCapacity and maxRingCapacity bound what Wintun accepts for a
+	// session's ring capacity; it must also be a power of two.
+	minRingCapacity     = 0x20000   // 128 KiB
+	maxRingCapacity     = 0x4000000 // 64 MiB
+	defaultRingCapacity = 0x800000  // 8 MiB
+)
+
+// validateRingCapacity reports whether capacity is within Wintun's allowed
+// ring capacity range and a power of two.
+func validateRingCapacity(capacity uint32) error {
+	if capacity < minRingCapacity || capacity > maxRingCapacity {
+		return fmt.Errorf("ring capacity %#x is outside Wintun's allowed range [%#x, %#x]", capacity, uint32(minRingCapacity), uint32(maxRingCapacity))
+	}
+	if capacity&(capacity-1) != 0 {
+		return fmt.Errorf("ring capacity %#x is not a power of two", capacity)
+	}
+	return nil
+}
+
+// CreateTUNWithRequestedGUID creates a Wintun interface with the given name,
+// tunnel type and requested GUID. Should a Wintun interface with the same
+// name exist, it is reused. tunnelType defaults to WintunTunnelType when
+// empty, and ringCapacity defaults to defaultRingCapacity when zero;
+// otherwise ringCapacity must satisfy validateRingCapacity. If
+// minDriverVersion is non-zero, session creation is refused when the loaded
+// Wintun driver reports an older version.
+func CreateTUNWithRequestedGUID(ifname, tunnelType string, requestedGUID *windows.GUID, mtu int, ringCapacity, minDriverVersion uint32) (*NativeTun, error) {
+	if minDriverVersion > 0 {
+		version, err := wintun.RunningVersion()
+		if err != nil {
+			return nil, fmt.Errorf("Error checking driver version: %w", err)
+		}
+		if version < minDriverVersion {
+			return nil, fmt.Errorf("Wintun driver version 0x%x is older than required minimum 0x%x", version, minDriverVersion)
+		}
+	}
+
+	if ringCapacity == 0 {
+		ringCapacity = defaultRingCapacity
+	} else if err := validateRingCapacity(ringCapacity); err != nil {
+		return nil, err
+	}
+
+	if tunnelType == "" {
+		tunnelType = WintunTunnelType
+	}
+	wt, err := wintun.CreateAdapter(ifname, tunnelType, requestedGUID)
 	if err != nil {
 		return nil, fmt.Errorf("Error creating interface: %w", err)
 	}
@@ -112,19 +342,78 @@ func CreateTUNWithRequestedGUID(ifname string, requestedGUID *windows.GUID, mtu
 		name:      ifname,
 		handle:    windows.InvalidHandle,
 		events:    make(chan Event, 10),
-		forcedMTU: forcedMTU,
+		forcedMTU: int32(forcedMTU),
 	}
 
-	tun.session, err = wt.StartSession(0x800000) // Ring capacity, 8 MiB
+	tun.session, err = wt.StartSession(ringCapacity)
 	if err != nil {
 		tun.wt.Close()
 		close(tun.events)
 		return nil, fmt.Errorf("Error starting session: %w", err)
 	}
 	tun.readWait = tun.session.ReadWaitEvent()
+
+	tun.ifaceChangeCB, err = winipcfg.RegisterInterfaceChangeCallback(tun.interfaceChanged)
+	if err != nil {
+		tun.session.End()
+		tun.wt.Close()
+		close(tun.events)
+		return nil, fmt.Errorf("Error registering interface change callback: %w", err)
+	}
+	tun.routeChangeCB, err = winipcfg.RegisterRouteChangeCallback(tun.routeChanged)
+	if err != nil {
+		tun.ifaceChangeCB.Unregister()
+		tun.session.End()
+		tun.wt.Close()
+		close(tun.events)
+		return nil, fmt.Errorf("Error registering route change callback: %w", err)
+	}
 	return tun, nil
 }
 
+// interfaceChanged reacts to winipcfg.RegisterInterfaceChangeCallback
+// notifications scoped to this adapter's LUID, picking up MTU changes made
+// outside of ForceMTU (e.g. by another process, or a driver reload). It runs
+// on a callback thread outside caller control, so the event send must never
+// block: Close waits for this callback to return before it can finish
+// unregistering it, so a full events channel with no reader would otherwise
+// deadlock Close.
+func (tun *NativeTun) interfaceChanged(notificationType winipcfg.MibNotificationType, iface *winipcfg.MibIPInterfaceRow) {
+	if notificationType != winipcfg.MibParameterNotification || iface == nil || iface.InterfaceLUID != winipcfg.LUID(tun.wt.LUID()) {
+		return
+	}
+	if mtu := int32(iface.NLMTU); mtu > 0 && atomic.SwapInt32(&tun.forcedMTU, mtu) != mtu {
+		select {
+		case tun.events <- EventMTUUpdate:
+		default:
+		}
+	}
+}
+
+// routeChanged reacts to winipcfg.RegisterRouteChangeCallback
+// notifications scoped to this adapter's LUID. The appearance/disappearance
+// of a default route on the adapter is used as a proxy for link up/down,
+// e.g. when a VPN roams or the adapter is reconfigured. See interfaceChanged
+// for why the event send must be non-blocking.
+func (tun *NativeTun) routeChanged(notificationType winipcfg.MibNotificationType, route *winipcfg.MibIPforwardRow2) {
+	if route == nil || route.InterfaceLUID != winipcfg.LUID(tun.wt.LUID()) || route.DestinationPrefix.PrefixLength != 0 {
+		return
+	}
+	var event Event
+	switch notificationType {
+	case winipcfg.MibAddInstance:
+		event = EventUp
+	case winipcfg.MibDeleteInstance:
+		event = EventDown
+	default:
+		return
+	}
+	select {
+	case tun.events <- event:
+	default:
+	}
+}
+
 func (tun *NativeTun) Name() (string, error) {
 	return tun.name, nil
 }
@@ -143,6 +432,12 @@ func (tun *NativeTun) Close() error {
 		tun.close.Store(true)
 		windows.SetEvent(tun.readWait)
 		tun.running.Wait()
+		if tun.ifaceChangeCB != nil {
+			tun.ifaceChangeCB.Unregister()
+		}
+		if tun.routeChangeCB != nil {
+			tun.routeChangeCB.Unregister()
+		}
 		tun.session.End()
 		if tun.wt != nil {
 			tun.wt.Close()
@@ -153,14 +448,15 @@ func (tun *NativeTun) Close() error {
 }
 
 func (tun *NativeTun) MTU() (int, error) {
-	return tun.forcedMTU, nil
+	return int(atomic.LoadInt32(&tun.forcedMTU)), nil
 }
 
-// TODO: This is a temporary hack. We really need to be monitoring the interface in real time and adapting to MTU changes.
+// ForceMTU overrides the MTU reported by MTU(), emitting EventMTUUpdate if
+// it changed. interfaceChanged keeps this in sync with the OS automatically,
+// so this is mainly useful for callers that want to apply an MTU before the
+// OS-level change has propagated.
 func (tun *NativeTun) ForceMTU(mtu int) {
-	update := tun.forcedMTU != mtu
-	tun.forcedMTU = mtu
-	if update {
+	if atomic.SwapInt32(&tun.forcedMTU, int32(mtu)) != int32(mtu) {
 		tun.events <- EventMTUUpdate
 	}
 }
@@ -185,15 +481,16 @@ retry:
 			packetSize := len(packet)
 			copy(buff[offset:], packet)
 			tun.session.ReleaseReceivePacket(packet)
+			tun.rate.update(uint64(packetSize))
 
 			return packetSize, nil
 		case windows.ERROR_NO_MORE_ITEMS:
-			if uint64(nanotime()-start) >= spinloopDuration {
-				windows.WaitForSingleObject(tun.readWait, windows.INFINITE)
-				goto retry
+			if atomic.LoadUint64(&tun.rate.current) >= spinloopRateThreshold && uint64(nanotime()-start) < spinloopDuration {
+				procyield(1)
+				continue
 			}
-			procyield(1)
-			continue
+			windows.WaitForSingleObject(tun.readWait, windows.INFINITE)
+			goto retry
 		case windows.ERROR_HANDLE_EOF:
 			return 0, os.ErrClosed
 		case windows.ERROR_INVALID_DATA:
@@ -220,6 +517,7 @@ func (tun *NativeTun) Write(buff []byte, offset int) (int, error) {
 	if err == nil {
 		copy(packet, buff[offset:])
 		tun.session.SendPacket(packet)
+		tun.rate.update(uint64(packetSize))
 		return packetSize, nil
 	}
 	switch err {
@@ -231,6 +529,80 @@ func (tun *NativeTun) Write(buff []byte, offset int) (int, error) {
 	return 0, fmt.Errorf("Write failed: %w", err)
 }
 
+// ReadPackets drains up to len(buffs) packets from the receive ring into
+// buffs, recording each packet's length in sizes. It only blocks on
+// readWait if no packet was available at all; once at least one packet has
+// been collected, an empty ring simply ends the batch early.
+func (tun *NativeTun) ReadPackets(buffs [][]byte, sizes []int, offset int) (int, error) {
+	tun.running.Add(1)
+	defer tun.running.Done()
+retry:
+	if tun.close.Load().(bool) {
+		return 0, os.ErrClosed
+	}
+	start := nanotime()
+	count := 0
+	for count < len(buffs) {
+		packet, err := tun.session.ReceivePacket()
+		switch err {
+		case nil:
+			packetSize := len(packet)
+			copy(buffs[count][offset:], packet)
+			sizes[count] = packetSize
+			tun.session.ReleaseReceivePacket(packet)
+			tun.rate.update(uint64(packetSize))
+			count++
+			continue
+		case windows.ERROR_NO_MORE_ITEMS:
+			if count > 0 {
+				return count, nil
+			}
+			if atomic.LoadUint64(&tun.rate.current) >= spinloopRateThreshold && uint64(nanotime()-start) < spinloopDuration {
+				procyield(1)
+				continue
+			}
+			windows.WaitForSingleObject(tun.readWait, windows.INFINITE)
+			goto retry
+		case windows.ERROR_HANDLE_EOF:
+			return count, os.ErrClosed
+		case windows.ERROR_INVALID_DATA:
+			return count, errors.New("Send ring corrupt")
+		}
+		return count, fmt.Errorf("Read failed: %w", err)
+	}
+	return count, nil
+}
+
+// WritePackets allocates, copies and commits each of buffs[i][offset:] as
+// its own packet in turn, so several packets go out in one call rather
+// than one packet per Write call.
+func (tun *NativeTun) WritePackets(buffs [][]byte, offset int) (int, error) {
+	tun.running.Add(1)
+	defer tun.running.Done()
+	if tun.close.Load().(bool) {
+		return 0, os.ErrClosed
+	}
+
+	for i, buff := range buffs {
+		packetSize := len(buff) - offset
+		packet, err := tun.session.AllocateSendPacket(packetSize)
+		if err == nil {
+			copy(packet, buff[offset:])
+			tun.session.SendPacket(packet)
+			tun.rate.update(uint64(packetSize))
+			continue
+		}
+		switch err {
+		case windows.ERROR_HANDLE_EOF:
+			return i, os.ErrClosed
+		case windows.ERROR_BUFFER_OVERFLOW:
+			return i, nil // Dropping when ring is full.
+		}
+		return i, fmt.Errorf("Write failed: %w", err)
+	}
+	return len(buffs), nil
+}
+
 // LUID returns Windows interface instance ID.
 func (tun *NativeTun) LUID() uint64 {
 	tun.running.Add(1)